@@ -0,0 +1,34 @@
+package deej
+
+// HttpConfig is the optional http: block in config.yaml, letting HttpIO be
+// exposed beyond localhost:6332 with TLS and bearer-token auth. config.go
+// isn't part of this checkout (same as deej.go/serial.go), so this is the
+// shape CanonicalConfig.Http needs to have for hio.deej.config.Http.* to
+// resolve - ShutdownTimeoutMs and WebsocketPingIntervalMs below are
+// top-level config.yaml keys, not nested under http:, matching how
+// NewHttpIO already reads deej.config.ShutdownTimeoutMs directly.
+//
+// Every field here defaults to its zero value when omitted from
+// config.yaml, and HttpIO already treats every zero value as "off": an
+// existing install with no http: block keeps binding localhost:6332 with no
+// TLS or auth, exactly as before this block existed
+type HttpConfig struct {
+	Bind          string   `yaml:"bind"`
+	TLSCert       string   `yaml:"tls_cert"`
+	TLSKey        string   `yaml:"tls_key"`
+	AutoTLSDomain string   `yaml:"auto_tls_domain"`
+	AuthTokens    []string `yaml:"auth_tokens"`
+	InsecureBind  bool     `yaml:"insecure_bind"`
+}
+
+// CanonicalConfig additionally needs, alongside `Http HttpConfig`:
+//
+//	ShutdownTimeoutMs       int `yaml:"shutdown_timeout_ms"`
+//	WebsocketPingIntervalMs int `yaml:"websocket_ping_interval_ms"`
+//
+// plus a ConfigDir() string method returning the directory config.yaml was
+// loaded from, used as the base for the autocert cache dir. All three are
+// read directly off *Deej.config by NewHttpIO/Start in http.go; none of them
+// have defaults to fill in here because NewHttpIO already falls back to
+// defaultShutdownTimeout/defaultWsPingInterval when the configured value is
+// <= 0
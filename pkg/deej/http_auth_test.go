@@ -0,0 +1,67 @@
+package deej
+
+import "testing"
+
+func TestExtractBearerToken(t *testing.T) {
+	cases := []struct {
+		header string
+		want   string
+	}{
+		{"Bearer abc123", "abc123"},
+		{"Bearer ", ""},
+		{"bearer abc123", ""}, // case-sensitive, matches the RFC 6750 scheme name
+		{"Basic abc123", ""},
+		{"", ""},
+	}
+
+	for _, c := range cases {
+		if got := extractBearerToken(c.header); got != c.want {
+			t.Errorf("extractBearerToken(%q) = %q, want %q", c.header, got, c.want)
+		}
+	}
+}
+
+func TestAuthTokenMatches(t *testing.T) {
+	tokens := []string{"token-a", "token-b"}
+
+	if !authTokenMatches("token-a", tokens) {
+		t.Error("expected token-a to match")
+	}
+
+	if !authTokenMatches("token-b", tokens) {
+		t.Error("expected token-b to match")
+	}
+
+	if authTokenMatches("token-c", tokens) {
+		t.Error("expected token-c not to match")
+	}
+
+	if authTokenMatches("", nil) {
+		t.Error("expected no tokens configured to never match")
+	}
+}
+
+func TestBindAddrAllowed(t *testing.T) {
+	cases := []struct {
+		name         string
+		addr         string
+		insecureBind bool
+		tlsEnabled   bool
+		want         bool
+	}{
+		{"localhost with nothing configured", "localhost:6332", false, false, true},
+		{"loopback ip with nothing configured", "127.0.0.1:6332", false, false, true},
+		{"loopback ip without port", "127.0.0.1", false, false, true},
+		{"lan address with nothing configured", "0.0.0.0:6332", false, false, false},
+		{"lan address with insecure_bind", "0.0.0.0:6332", true, false, true},
+		{"lan address with tls", "0.0.0.0:6332", false, true, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := bindAddrAllowed(c.addr, c.insecureBind, c.tlsEnabled); got != c.want {
+				t.Errorf("bindAddrAllowed(%q, %v, %v) = %v, want %v", c.addr, c.insecureBind, c.tlsEnabled, got, c.want)
+			}
+		})
+	}
+}
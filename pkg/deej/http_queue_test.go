@@ -0,0 +1,77 @@
+package deej
+
+import "testing"
+
+func TestSliderEventQueuePushPop(t *testing.T) {
+	q := newSliderEventQueue()
+
+	if _, ok := q.pop(); ok {
+		t.Fatal("pop on empty queue should return ok=false")
+	}
+
+	q.push(SliderMoveEvent{SliderID: 0, PercentValue: 0.1})
+	q.push(SliderMoveEvent{SliderID: 1, PercentValue: 0.2})
+
+	ev, ok := q.pop()
+	if !ok || ev.SliderID != 0 || ev.PercentValue != 0.1 {
+		t.Fatalf("expected first-in slider 0 at 0.1, got %+v (ok=%v)", ev, ok)
+	}
+
+	ev, ok = q.pop()
+	if !ok || ev.SliderID != 1 || ev.PercentValue != 0.2 {
+		t.Fatalf("expected slider 1 at 0.2, got %+v (ok=%v)", ev, ok)
+	}
+
+	if _, ok := q.pop(); ok {
+		t.Fatal("pop after draining the queue should return ok=false")
+	}
+}
+
+func TestSliderEventQueueCoalescesWhenFull(t *testing.T) {
+	q := newSliderEventQueue()
+
+	for i := 0; i < sliderEventQueueSize; i++ {
+		q.push(SliderMoveEvent{SliderID: 0, PercentValue: float32(i)})
+	}
+
+	// one more update for the same slider should collapse into its existing
+	// queued entry rather than growing the queue or blocking
+	q.push(SliderMoveEvent{SliderID: 0, PercentValue: 0.99})
+
+	count := 0
+	var last SliderMoveEvent
+	for {
+		ev, ok := q.pop()
+		if !ok {
+			break
+		}
+		count++
+		last = ev
+	}
+
+	if count != sliderEventQueueSize {
+		t.Fatalf("expected queue to stay bounded at %d, got %d", sliderEventQueueSize, count)
+	}
+
+	if last.PercentValue != 0.99 {
+		t.Fatalf("expected the coalesced update to win, got %+v", last)
+	}
+}
+
+func TestSliderEventQueueDropsOldestWhenFullAndDistinct(t *testing.T) {
+	q := newSliderEventQueue()
+
+	for i := 0; i < sliderEventQueueSize; i++ {
+		q.push(SliderMoveEvent{SliderID: i, PercentValue: 0})
+	}
+
+	// a slider id that isn't already queued can't be collapsed into an
+	// existing entry, so the oldest queued event should be dropped instead
+	// of blocking the producer
+	q.push(SliderMoveEvent{SliderID: sliderEventQueueSize, PercentValue: 1})
+
+	ev, ok := q.pop()
+	if !ok || ev.SliderID != 1 {
+		t.Fatalf("expected slider 0 to have been dropped and slider 1 to be oldest, got %+v (ok=%v)", ev, ok)
+	}
+}
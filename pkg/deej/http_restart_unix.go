@@ -0,0 +1,28 @@
+//go:build !windows
+
+package deej
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// setupRestartSignal listens for SIGUSR2 and triggers a graceful hot-restart,
+// letting users reload deej (e.g. after editing config.yaml) without
+// dropping long-lived SSE/WebSocket clients
+func (hio *HttpIO) setupRestartSignal() {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGUSR2)
+
+	go func() {
+		for range sigChan {
+			hio.logger.Info("Received SIGUSR2, starting graceful restart")
+			hio.doGracefulRestart()
+		}
+	}()
+}
+
+// closeRestartPipeListener is a no-op on Unix, which triggers restarts via
+// SIGUSR2 rather than a named pipe
+func (hio *HttpIO) closeRestartPipeListener() {}
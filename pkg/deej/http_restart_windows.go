@@ -0,0 +1,67 @@
+//go:build windows
+
+package deej
+
+import (
+	"fmt"
+
+	"github.com/Microsoft/go-winio"
+)
+
+// restartPipeName is the named pipe external tools (the tray icon, an
+// installer's post-update hook) connect to in order to trigger a graceful
+// restart - Windows has no SIGUSR2 equivalent, so this is deej's substitute
+// for `kill -USR2 $(pidof deej)`
+const restartPipeName = `\\.\pipe\deej-restart`
+
+// setupRestartSignal listens on a named pipe for a restart signal. Any
+// client that connects triggers a graceful hot-restart, same as SIGUSR2 does
+// on Unix
+func (hio *HttpIO) setupRestartSignal() {
+	listener, err := winio.ListenPipe(restartPipeName, nil)
+	if err != nil {
+		hio.logger.Warnw("Failed to listen on restart named pipe", "error", err, "pipe", restartPipeName)
+		return
+	}
+
+	hio.restartPipeListener = listener
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				// expected once closeRestartPipeListener closes the listener during shutdown
+				return
+			}
+
+			hio.logger.Info("Received restart signal on named pipe, starting graceful restart")
+			conn.Close()
+			hio.doGracefulRestart()
+		}
+	}()
+}
+
+// closeRestartPipeListener closes the restart named pipe listener opened by
+// setupRestartSignal, if any, so shutdown() doesn't leave it dangling
+func (hio *HttpIO) closeRestartPipeListener() {
+	if hio.restartPipeListener != nil {
+		hio.restartPipeListener.Close()
+	}
+}
+
+// Restart triggers a graceful hot-restart of the HTTP subsystem by dialing
+// our own restart named pipe, giving in-process Windows callers (e.g. the
+// tray menu) the same entry point external tools use
+func (d *Deej) Restart() error {
+	if d.http == nil {
+		return nil
+	}
+
+	conn, err := winio.DialPipe(restartPipeName, nil)
+	if err != nil {
+		return fmt.Errorf("dial restart pipe: %w", err)
+	}
+	defer conn.Close()
+
+	return nil
+}